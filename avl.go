@@ -19,7 +19,7 @@ package avl
 // The primary differences from the original package are:
 //  * The balance factor is not stored separately from the parent pointer.
 //  * The container is non-intrusive.
-//  * Only in-order traversal is currently supported.
+//  * Only in-order traversal (forward or backward) is currently supported.
 
 import "errors"
 
@@ -43,7 +43,8 @@ type Direction int
 const (
 	// Forward is forward in-order.
 	Forward Direction = 1
-	it_sign int       = 1
+	// Backward is reverse in-order.
+	Backward Direction = -1
 )
 
 // Node is a node of a Tree.
@@ -54,24 +55,64 @@ type Node[T, K any] struct {
 
 	parent, left, right *Node[T, K]
 	balance             int
+
+	// height is the subtree height.  It backs the applicative (Persistent)
+	// insert/remove path in persistent.go and, on the mutable Tree, the
+	// O(log n) Join/Split primitives in join.go; ordinary Insert/Remove
+	// maintain it incrementally alongside balance.
+	height int
+
+	// size is the number of Nodes in the subtree rooted at this Node
+	// (including itself), maintained by Insert/InsertNode/Remove and kept
+	// correct across rotations.  It backs Rank/Select/CountRange/Range.
+	size int
+}
+
+func subtreeSize[T, K any](n *Node[T, K]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *Node[T, K]) recomputeSize() {
+	n.size = 1 + subtreeSize(n.left) + subtreeSize(n.right)
+}
+
+// recomputeHeight derives n.height from its children's (already-correct)
+// heights.  heightOf is defined in persistent.go, where it serves the same
+// purpose for applicative insert/remove.
+func (n *Node[T, K]) recomputeHeight() {
+	lh, rh := heightOf(n.left), heightOf(n.right)
+	if lh > rh {
+		n.height = lh + 1
+	} else {
+		n.height = rh + 1
+	}
 }
 
 // Iterator is a Tree iterator.  Modifying the Tree while iterating is
 // unsupported except for removing the current Node.
 type Iterator[T, K any] struct {
-	tree      *Tree[T, K]
-	cur, next *Node[T, K]
-	//sign        int
+	tree        *Tree[T, K]
+	cur, next   *Node[T, K]
+	sign        int
 	initialized bool
 }
 
 // First moves the iterator to the first Node in the Tree and returns the
 // first Node or nil iff the Tree is empty.  Note that "first" in this context
-// is dependent on the direction specified when constructing the iterator.
+// is dependent on the direction specified when constructing the iterator:
+// the smallest key when Forward, the largest when Backward.
 func (it *Iterator[T, K]) First() *Node[T, K] {
-	it.cur, it.next = it.tree.First(), nil
+	if it.sign < 0 {
+		it.cur = it.tree.Last()
+	} else {
+		it.cur = it.tree.First()
+	}
+	it.next = nil
 	if it.cur != nil {
-		it.next = it.cur.nextOrPrevInOrder(it_sign)
+		it.next = it.cur.nextOrPrevInOrder(it.sign)
 	}
 	it.initialized = true
 	return it.cur
@@ -97,7 +138,64 @@ func (it *Iterator[T, K]) Next() *Node[T, K] {
 		return nil
 	}
 
-	it.next = it.cur.nextOrPrevInOrder(it_sign)
+	it.next = it.cur.nextOrPrevInOrder(it.sign)
+	return it.cur
+}
+
+// Prev moves the iterator one step against its configured direction (the
+// in-order predecessor when Forward, the in-order successor when Backward)
+// and returns the Node or nil iff there is no such element.  A subsequent
+// Next() continues on from the new position.
+func (it *Iterator[T, K]) Prev() *Node[T, K] {
+	if !it.initialized {
+		it.First()
+	}
+	if it.cur == nil {
+		return nil
+	}
+
+	prev := it.cur.nextOrPrevInOrder(-it.sign)
+	if prev == nil {
+		return nil
+	}
+	it.cur = prev
+	it.next = it.cur.nextOrPrevInOrder(it.sign)
+	return it.cur
+}
+
+// Seek positions the iterator at the first element in its direction that is
+// not closer to the start than key - that is, the smallest key' >= key when
+// Forward, or the largest key' <= key when Backward - and returns it, or nil
+// if no such element exists.  LowerBound is an alias kept for readability at
+// call sites that think in terms of ordered bounds rather than iteration.
+func (it *Iterator[T, K]) Seek(key K) *Node[T, K] {
+	return it.seek(key, false)
+}
+
+// LowerBound is equivalent to Seek.
+func (it *Iterator[T, K]) LowerBound(key K) *Node[T, K] {
+	return it.seek(key, false)
+}
+
+// UpperBound positions the iterator at the first element strictly past key
+// in its direction - that is, the smallest key' > key when Forward, or the
+// largest key' < key when Backward - and returns it, or nil if no such
+// element exists.
+func (it *Iterator[T, K]) UpperBound(key K) *Node[T, K] {
+	return it.seek(key, true)
+}
+
+func (it *Iterator[T, K]) seek(key K, strict bool) *Node[T, K] {
+	if it.tree.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+
+	it.cur = it.tree.boundedSearch(key, it.sign, strict)
+	it.initialized = true
+	it.next = nil
+	if it.cur != nil {
+		it.next = it.cur.nextOrPrevInOrder(it.sign)
+	}
 	return it.cur
 }
 
@@ -105,6 +203,8 @@ func (n *Node[T, K]) reset() {
 	// Note: This deliberately leaves Value intact.
 	n.parent, n.left, n.right = n, nil, nil
 	n.balance = 0
+	n.size = 1
+	n.height = 1
 }
 
 func (n *Node[T, K]) setParentBalance(parent *Node[T, K], balance int) {
@@ -158,6 +258,11 @@ type Tree[T, K any] struct {
 	first *Node[T, K]
 	cmpFn CompareFunc[K]
 	size  int
+
+	// allowDup makes the Tree a multimap: Insert/InsertNode keep descending
+	// on a tie instead of returning the existing Node.  Set only by
+	// NewMulti.
+	allowDup bool
 }
 
 // Len returns the number of elements in the Tree.
@@ -224,11 +329,17 @@ func (t *Tree[T, K]) Insert(k K, v T) *Node[T, K] {
 		cmp := t.cmpFn(k, cur.Key)
 		switch {
 		case cmp < 0:
+			cur.size++
 			curPtr = &cur.left
 		case cmp > 0:
+			cur.size++
 			curPtr = &cur.right
 		default:
-			return cur
+			if !t.allowDup {
+				return cur
+			}
+			cur.size++
+			curPtr = &cur.right
 		}
 	}
 
@@ -237,6 +348,8 @@ func (t *Tree[T, K]) Insert(k K, v T) *Node[T, K] {
 		Key:     k,
 		parent:  cur,
 		balance: 0,
+		size:    1,
+		height:  1,
 	}
 	*curPtr = n
 	t.rebalanceAfterInsert(n)
@@ -265,11 +378,17 @@ func (t *Tree[T, K]) InsertNode(n *Node[T, K]) *Node[T, K] {
 		cmp := t.cmpFn(k, cur.Key)
 		switch {
 		case cmp < 0:
+			cur.size++
 			curPtr = &cur.left
 		case cmp > 0:
+			cur.size++
 			curPtr = &cur.right
 		default:
-			return cur
+			if !t.allowDup {
+				return cur
+			}
+			cur.size++
+			curPtr = &cur.right
 		}
 	}
 
@@ -283,7 +402,10 @@ func (t *Tree[T, K]) InsertNode(n *Node[T, K]) *Node[T, K] {
 	return n
 }
 
-// Remove removes the Node from the Tree.
+// Remove removes the Node from the Tree.  Because it identifies the Node to
+// remove by pointer rather than by re-searching for its key, it removes
+// exactly the Node passed in even on a Tree created via NewMulti, where
+// other Nodes may share the same key.
 func (t *Tree[T, K]) Remove(node *Node[T, K]) {
 	var parent *Node[T, K]
 	var leftDeleted bool
@@ -298,6 +420,9 @@ func (t *Tree[T, K]) Remove(node *Node[T, K]) {
 	}
 
 	t.size--
+	for anc := node.parent; anc != nil; anc = anc.parent {
+		anc.size--
+	}
 	if node.left != nil && node.right != nil {
 		parent, leftDeleted = t.swapWithSuccessor(node)
 	} else {
@@ -345,15 +470,42 @@ func (t *Tree[T, K]) Remove(node *Node[T, K]) {
 // except for removing the current Node.
 func (t *Tree[T, K]) Iterator(direction Direction) *Iterator[T, K] {
 	switch direction {
-	case Forward:
+	case Forward, Backward:
 	default:
 		panic(errInvalidDirection)
 	}
 
 	return &Iterator[T, K]{
 		tree: t,
-		//sign: 1, //int(direction),
+		sign: int(direction),
+	}
+}
+
+// boundedSearch returns the closest Node to key in the direction given by
+// sign: the smallest Node with Key >= key (sign > 0) or the largest Node
+// with Key <= key (sign < 0); strict excludes an exact match, tightening
+// the bound to > key or < key respectively.  It returns nil if no Node
+// satisfies the bound.
+func (t *Tree[T, K]) boundedSearch(key K, sign int, strict bool) *Node[T, K] {
+	var best *Node[T, K]
+	cur := t.root
+	for cur != nil {
+		cmp := t.cmpFn(key, cur.Key)
+		var withinBound bool
+		if sign > 0 {
+			withinBound = cmp < 0 || (!strict && cmp == 0)
+		} else {
+			withinBound = cmp > 0 || (!strict && cmp == 0)
+		}
+
+		if withinBound {
+			best = cur
+			cur = cur.getChild(-sign)
+		} else {
+			cur = cur.getChild(sign)
+		}
 	}
+	return best
 }
 
 // ForEach executes a function for each Node in the tree, visiting the nodes
@@ -411,6 +563,11 @@ func (t *Tree[T, K]) rotate(a *Node[T, K], sign int) {
 	}
 
 	t.replaceChild(p, a, b)
+
+	a.recomputeSize()
+	b.recomputeSize()
+	a.recomputeHeight()
+	b.recomputeHeight()
 }
 
 func (t *Tree[T, K]) doDoubleRotate(b, a *Node[T, K], sign int) *Node[T, K] {
@@ -448,6 +605,13 @@ func (t *Tree[T, K]) doDoubleRotate(b, a *Node[T, K], sign int) *Node[T, K] {
 
 	t.replaceChild(p, a, e)
 
+	a.recomputeSize()
+	b.recomputeSize()
+	e.recomputeSize()
+	a.recomputeHeight()
+	b.recomputeHeight()
+	e.recomputeHeight()
+
 	return e
 }
 
@@ -455,6 +619,7 @@ func (t *Tree[T, K]) handleSubtreeGrowth(node, parent *Node[T, K], sign int) boo
 	oldBalanceFactor := parent.balance
 	if oldBalanceFactor == 0 {
 		parent.adjustBalanceFactor(sign)
+		parent.height++
 		return false
 	}
 
@@ -489,6 +654,7 @@ func (t *Tree[T, K]) rebalanceAfterInsert(inserted *Node[T, K]) {
 	if parent.balance == 0 {
 		return
 	}
+	parent.height++
 
 	for done := false; !done; {
 		node = parent
@@ -516,6 +682,7 @@ func (t *Tree[T, K]) swapWithSuccessor(x *Node[T, K]) (*Node[T, K], bool) {
 
 		for {
 			q = y
+			q.size--
 			if y = y.left; y.left == nil {
 				break
 			}
@@ -535,6 +702,8 @@ func (t *Tree[T, K]) swapWithSuccessor(x *Node[T, K]) (*Node[T, K], bool) {
 
 	y.parent = x.parent
 	y.balance = x.balance
+	y.size = x.size - 1
+	y.height = x.height
 
 	t.replaceChild(x.parent, x, y)
 
@@ -553,6 +722,7 @@ func (t *Tree[T, K]) handleSubtreeShrink(parent *Node[T, K], sign int,
 	newBalanceFactor := oldBalanceFactor + sign
 	if newBalanceFactor == 0 {
 		parent.adjustBalanceFactor(sign)
+		parent.height--
 		node = parent
 	} else {
 		node = parent.getChild(sign)
@@ -0,0 +1,89 @@
+// Package concurrent provides a concurrency-safe wrapper around
+// avl.Persistent for cache and index workloads with many readers and a
+// single writer.
+//
+// ConcurrentTree publishes immutable avl.Persistent snapshots through an
+// atomic.Pointer: writers serialize on a mutex, build a new snapshot via
+// Persistent's path-copy Insert/Remove, and publish it with a single atomic
+// store; readers load the pointer once and walk the resulting snapshot
+// lock-free, never blocking on or racing with a writer.
+package concurrent
+
+import (
+	"sync"
+	"sync/atomic"
+
+	avl "github.com/kjx98/go-avl"
+)
+
+// ConcurrentTree wraps an avl.Persistent[T, K] behind an atomic.Pointer, so
+// that Load and the convenience read methods never take a lock.
+type ConcurrentTree[T, K any] struct {
+	mu    sync.Mutex
+	cmpFn avl.CompareFunc[K]
+	root  atomic.Pointer[avl.Persistent[T, K]]
+}
+
+// NewConcurrentTree returns an empty ConcurrentTree ordered by cmpFn.
+func NewConcurrentTree[T, K any](cmpFn avl.CompareFunc[K]) *ConcurrentTree[T, K] {
+	c := &ConcurrentTree[T, K]{cmpFn: cmpFn}
+	c.root.Store(avl.NewPersistent[T, K](cmpFn))
+	return c
+}
+
+// Load returns the ConcurrentTree's current snapshot.  The returned
+// *avl.Persistent is immutable and safe to read from any goroutine without
+// further synchronization, including while a writer is publishing a new one.
+func (c *ConcurrentTree[T, K]) Load() *avl.Persistent[T, K] {
+	return c.root.Load()
+}
+
+// Insert adds an entry and publishes the resulting snapshot.  If key k is
+// already present, the existing entry (and its value) is kept unchanged.
+// Insert takes c's writer lock, so concurrent writers are serialized;
+// concurrent readers are unaffected.
+func (c *ConcurrentTree[T, K]) Insert(k K, v T) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.root.Store(c.root.Load().Insert(k, v))
+}
+
+// Remove deletes the entry with the given key, if present, and publishes the
+// resulting snapshot.  Remove takes c's writer lock, so concurrent writers
+// are serialized; concurrent readers are unaffected.
+func (c *ConcurrentTree[T, K]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.root.Store(c.root.Load().Remove(key))
+}
+
+// Len returns the number of entries in c's current snapshot.
+func (c *ConcurrentTree[T, K]) Len() int {
+	return c.Load().Len()
+}
+
+// Find returns the Node for key in c's current snapshot, or nil if key is
+// not present.
+func (c *ConcurrentTree[T, K]) Find(key K) *avl.Node[T, K] {
+	return c.Load().Find(key)
+}
+
+// ForEach visits every Node of c's current snapshot in ascending key order,
+// stopping early if fn returns false.
+func (c *ConcurrentTree[T, K]) ForEach(fn func(*avl.Node[T, K]) bool) {
+	c.Load().ForEach(fn)
+}
+
+// Range visits every Node of c's current snapshot whose key falls within
+// [lo, hi], in ascending key order, stopping early if fn returns false.
+func (c *ConcurrentTree[T, K]) Range(lo, hi K, fn func(*avl.Node[T, K]) bool) {
+	c.Load().ForEach(func(n *avl.Node[T, K]) bool {
+		if c.cmpFn(lo, n.Key) > 0 {
+			return true
+		}
+		if c.cmpFn(hi, n.Key) < 0 {
+			return false
+		}
+		return fn(n)
+	})
+}
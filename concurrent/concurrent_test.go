@@ -0,0 +1,142 @@
+// concurrent_test.go - ConcurrentTree read/write and snapshot isolation tests.
+
+package concurrent
+
+import (
+	"sync"
+	"testing"
+
+	avl "github.com/kjx98/go-avl"
+)
+
+func cmpInt(a, b int) int {
+	return a - b
+}
+
+func TestConcurrentTreeInsertAndFind(t *testing.T) {
+	c := NewConcurrentTree[string, int](cmpInt)
+	c.Insert(1, "a")
+	c.Insert(2, "b")
+
+	if c.Len() != 2 {
+		t.Fatalf("Len() = %v, want 2", c.Len())
+	}
+	if n := c.Find(1); n == nil || n.Value != "a" {
+		t.Fatalf("Find(1) = %v, want a", n)
+	}
+	if c.Find(3) != nil {
+		t.Fatalf("Find(3): expected nil for absent key")
+	}
+}
+
+func TestConcurrentTreeRemove(t *testing.T) {
+	c := NewConcurrentTree[string, int](cmpInt)
+	c.Insert(1, "a")
+	c.Insert(2, "b")
+
+	c.Remove(1)
+	if c.Len() != 1 {
+		t.Fatalf("Len() after Remove = %v, want 1", c.Len())
+	}
+	if c.Find(1) != nil {
+		t.Fatalf("Find(1) after Remove: expected nil")
+	}
+}
+
+func TestConcurrentTreeSnapshotIsolation(t *testing.T) {
+	c := NewConcurrentTree[string, int](cmpInt)
+	c.Insert(1, "a")
+
+	snap := c.Load()
+	c.Insert(2, "b")
+
+	if snap.Len() != 1 || snap.Find(2) != nil {
+		t.Fatalf("Load: snapshot observed a later write, Len() = %v", snap.Len())
+	}
+	if c.Load().Len() != 2 {
+		t.Fatalf("Load: current snapshot missing later write, Len() = %v", c.Load().Len())
+	}
+}
+
+func TestConcurrentTreeSnapshotSurvivesRemoveRebalancing(t *testing.T) {
+	c := NewConcurrentTree[int, int](cmpInt)
+	var keys []int
+	for i := 0; i < 40; i++ {
+		c.Insert(i, i*10)
+		keys = append(keys, i)
+	}
+
+	snap := c.Load()
+
+	for i := 0; i < 10; i++ {
+		c.Remove(keys[i])
+	}
+
+	var got []int
+	snap.ForEach(func(n *avl.Node[int, int]) bool {
+		got = append(got, n.Key)
+		return true
+	})
+	if len(got) != len(keys) {
+		t.Fatalf("Load: snapshot corrupted by later Removes, ForEach visited %v keys, want %v", len(got), len(keys))
+	}
+	for i, k := range keys {
+		if got[i] != k {
+			t.Fatalf("Load: snapshot corrupted by later Removes, got[%v] = %v, want %v", i, got[i], k)
+		}
+	}
+	for _, k := range keys {
+		if n := snap.Find(k); n == nil || n.Value != k*10 {
+			t.Fatalf("Load: snapshot missing or corrupted key %v after later Removes", k)
+		}
+	}
+
+	if c.Len() != len(keys)-10 {
+		t.Fatalf("Len() after Removes = %v, want %v", c.Len(), len(keys)-10)
+	}
+}
+
+func TestConcurrentTreeRange(t *testing.T) {
+	c := NewConcurrentTree[string, int](cmpInt)
+	for _, k := range []int{1, 2, 3, 4, 5} {
+		c.Insert(k, "v")
+	}
+
+	var got []int
+	c.Range(2, 4, func(n *avl.Node[string, int]) bool {
+		got = append(got, n.Key)
+		return true
+	})
+	want := []int{2, 3, 4}
+	if len(got) != len(want) {
+		t.Fatalf("Range(2, 4) = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Range(2, 4)[%v] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestConcurrentTreeConcurrentReadersAndWriter(t *testing.T) {
+	c := NewConcurrentTree[int, int](cmpInt)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			c.Insert(i, i)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		snap := c.Load()
+		snap.ForEach(func(n *avl.Node[int, int]) bool { return true })
+	}
+	wg.Wait()
+
+	if c.Len() != 100 {
+		t.Fatalf("Len() = %v, want 100", c.Len())
+	}
+}
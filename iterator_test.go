@@ -0,0 +1,126 @@
+// iterator_test.go - Backward iteration and bounded-cursor tests.
+
+package avl
+
+import (
+	"testing"
+)
+
+func TestIteratorBackward(t *testing.T) {
+	tree := New[int, int](cmpInt)
+	for _, v := range []int{30, 10, 50, 20, 40} {
+		tree.Insert(v, v)
+	}
+
+	it := tree.Iterator(Backward)
+	var got []int
+	for n := it.First(); n != nil; n = it.Next() {
+		got = append(got, n.Key)
+	}
+	want := []int{50, 40, 30, 20, 10}
+	if len(got) != len(want) {
+		t.Fatalf("Backward iteration: got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Backward iteration[%v] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	var forEachGot []int
+	tree.ForEach(Backward, func(n *Node[int, int]) bool {
+		forEachGot = append(forEachGot, n.Key)
+		return true
+	})
+	if len(forEachGot) != len(want) || forEachGot[0] != 50 || forEachGot[len(forEachGot)-1] != 10 {
+		t.Fatalf("ForEach(Backward) = %v, want %v", forEachGot, want)
+	}
+}
+
+func TestIteratorPrev(t *testing.T) {
+	tree := New[int, int](cmpInt)
+	for _, v := range []int{1, 2, 3, 4, 5} {
+		tree.Insert(v, v)
+	}
+
+	it := tree.Iterator(Forward)
+	it.First()
+	it.Next()
+	it.Next() // cur == 3
+
+	if n := it.Get(); n.Key != 3 {
+		t.Fatalf("Get() = %v, want 3", n.Key)
+	}
+	if n := it.Prev(); n == nil || n.Key != 2 {
+		t.Fatalf("Prev() = %v, want 2", n)
+	}
+	if n := it.Next(); n == nil || n.Key != 3 {
+		t.Fatalf("Next() after Prev() = %v, want 3", n)
+	}
+
+	// Prev() past the start returns nil.
+	it = tree.Iterator(Forward)
+	it.First()
+	if n := it.Prev(); n != nil {
+		t.Fatalf("Prev() at start = %v, want nil", n)
+	}
+}
+
+func TestIteratorSeekAndBounds(t *testing.T) {
+	tree := New[int, int](cmpInt)
+	for _, v := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(v, v)
+	}
+
+	fwd := tree.Iterator(Forward)
+	if n := fwd.LowerBound(25); n == nil || n.Key != 30 {
+		t.Fatalf("LowerBound(25) = %v, want 30", n)
+	}
+	if n := fwd.LowerBound(30); n == nil || n.Key != 30 {
+		t.Fatalf("LowerBound(30) = %v, want 30", n)
+	}
+	if n := fwd.UpperBound(30); n == nil || n.Key != 40 {
+		t.Fatalf("UpperBound(30) = %v, want 40", n)
+	}
+	if n := fwd.LowerBound(100); n != nil {
+		t.Fatalf("LowerBound(100) = %v, want nil", n)
+	}
+
+	var afterSeek []int
+	fwd.Seek(25)
+	for n := fwd.Get(); n != nil; n = fwd.Next() {
+		afterSeek = append(afterSeek, n.Key)
+	}
+	want := []int{30, 40, 50}
+	if len(afterSeek) != len(want) {
+		t.Fatalf("walk after Seek(25) = %v, want %v", afterSeek, want)
+	}
+	for i := range want {
+		if afterSeek[i] != want[i] {
+			t.Fatalf("walk after Seek(25)[%v] = %v, want %v", i, afterSeek[i], want[i])
+		}
+	}
+
+	bwd := tree.Iterator(Backward)
+	if n := bwd.LowerBound(25); n == nil || n.Key != 20 {
+		t.Fatalf("Backward LowerBound(25) = %v, want 20", n)
+	}
+	if n := bwd.UpperBound(20); n == nil || n.Key != 10 {
+		t.Fatalf("Backward UpperBound(20) = %v, want 10", n)
+	}
+
+	var bwdAfterSeek []int
+	bwd.Seek(25)
+	for n := bwd.Get(); n != nil; n = bwd.Next() {
+		bwdAfterSeek = append(bwdAfterSeek, n.Key)
+	}
+	wantBwd := []int{20, 10}
+	if len(bwdAfterSeek) != len(wantBwd) {
+		t.Fatalf("backward walk after Seek(25) = %v, want %v", bwdAfterSeek, wantBwd)
+	}
+	for i := range wantBwd {
+		if bwdAfterSeek[i] != wantBwd[i] {
+			t.Fatalf("backward walk after Seek(25)[%v] = %v, want %v", i, bwdAfterSeek[i], wantBwd[i])
+		}
+	}
+}
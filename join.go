@@ -0,0 +1,228 @@
+// join.go - O(n) bulk construction and O(log n) split/join for Trees.
+
+package avl
+
+// BuildSorted constructs a balanced Tree in O(n) from keys and values that
+// are already sorted according to cmpFn (ascending, with no duplicate
+// keys), by recursively picking the middle element and deriving balance
+// factors directly rather than inserting one element at a time and
+// rotating.
+func BuildSorted[T, K any](cmpFn CompareFunc[K], keys []K, values []T) *Tree[T, K] {
+	if cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	if len(keys) != len(values) {
+		panic("avl: BuildSorted: len(keys) != len(values)")
+	}
+
+	return &Tree[T, K]{
+		root:  buildBalanced(keys, values, nil),
+		cmpFn: cmpFn,
+		size:  len(keys),
+	}
+}
+
+func buildBalanced[T, K any](keys []K, values []T, parent *Node[T, K]) *Node[T, K] {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	mid := len(keys) / 2
+	n := &Node[T, K]{Value: values[mid], Key: keys[mid], parent: parent}
+	n.left = buildBalanced(keys[:mid], values[:mid], n)
+	n.right = buildBalanced(keys[mid+1:], values[mid+1:], n)
+	n.recomputeSize()
+	n.recomputeHeight()
+	n.balance = heightOf(n.right) - heightOf(n.left)
+	return n
+}
+
+// Join merges left and right into a single Tree in O(log n(left)+log
+// n(right)) and returns it.  Every key in left must compare less than every
+// key in right - Join does not check this.  left and right (and any Node
+// obtained from either) are consumed by the call and must not be used
+// afterwards.
+func Join[T, K any](left, right *Tree[T, K]) *Tree[T, K] {
+	switch {
+	case left.root == nil:
+		return right
+	case right.root == nil:
+		return left
+	}
+
+	pivot := left.Last()
+	k, v := pivot.Key, pivot.Value
+	left.Remove(pivot)
+
+	root := joinWithKey(left.root, k, v, right.root, left.cmpFn)
+	root.parent = nil
+	return &Tree[T, K]{
+		root:  root,
+		cmpFn: left.cmpFn,
+		size:  left.size + 1 + right.size,
+	}
+}
+
+// joinWithKey joins l, (k, v), and r - with every key of l less than k and
+// every key of r greater than k - into a single balanced subtree.
+func joinWithKey[T, K any](l *Node[T, K], k K, v T, r *Node[T, K], cmpFn CompareFunc[K]) *Node[T, K] {
+	lh, rh := heightOf(l), heightOf(r)
+	switch {
+	case lh > rh+1:
+		return joinRight(l, k, v, r)
+	case rh > lh+1:
+		return joinLeft(l, k, v, r)
+	default:
+		n := &Node[T, K]{Value: v, Key: k, left: l, right: r}
+		attachChildren(n)
+		return n
+	}
+}
+
+// joinRight attaches (k, v) and r under l's right spine, at the point where
+// the heights differ by at most 1, then rebalances with at most one single
+// or double rotation per level back up to the root.  It assumes height(l)
+// > height(r).
+func joinRight[T, K any](l *Node[T, K], k K, v T, r *Node[T, K]) *Node[T, K] {
+	if heightOf(l.right) <= heightOf(r)+1 {
+		n := &Node[T, K]{Value: v, Key: k, left: l.right, right: r}
+		attachChildren(n)
+
+		l.right = n
+		n.parent = l
+		recomputeNode(l)
+
+		return rebalanceJoin(l)
+	}
+
+	newRight := joinRight(l.right, k, v, r)
+	l.right = newRight
+	newRight.parent = l
+	recomputeNode(l)
+
+	return rebalanceJoin(l)
+}
+
+// joinLeft is the mirror of joinRight, for height(r) > height(l).
+func joinLeft[T, K any](l *Node[T, K], k K, v T, r *Node[T, K]) *Node[T, K] {
+	if heightOf(r.left) <= heightOf(l)+1 {
+		n := &Node[T, K]{Value: v, Key: k, left: l, right: r.left}
+		attachChildren(n)
+
+		r.left = n
+		n.parent = r
+		recomputeNode(r)
+
+		return rebalanceJoin(r)
+	}
+
+	newLeft := joinLeft(l, k, v, r.left)
+	r.left = newLeft
+	newLeft.parent = r
+	recomputeNode(r)
+
+	return rebalanceJoin(r)
+}
+
+func attachChildren[T, K any](n *Node[T, K]) {
+	if n.left != nil {
+		n.left.parent = n
+	}
+	if n.right != nil {
+		n.right.parent = n
+	}
+	recomputeNode(n)
+}
+
+func recomputeNode[T, K any](n *Node[T, K]) {
+	n.recomputeSize()
+	n.recomputeHeight()
+	n.balance = heightOf(n.right) - heightOf(n.left)
+}
+
+// rebalanceJoin restores the AVL invariant at n after joinRight/joinLeft
+// attached a subtree one level below it, picking a single or double
+// rotation based on the heavy child's balance sign - the same decision
+// rebalanceApplicative makes for the applicative Insert/Remove path.
+func rebalanceJoin[T, K any](n *Node[T, K]) *Node[T, K] {
+	switch {
+	case n.balance > 1:
+		if n.right.balance < 0 {
+			n.right = rotateRightJoin(n.right)
+			n.right.parent = n
+		}
+		return rotateLeftJoin(n)
+	case n.balance < -1:
+		if n.left.balance > 0 {
+			n.left = rotateLeftJoin(n.left)
+			n.left.parent = n
+		}
+		return rotateRightJoin(n)
+	default:
+		return n
+	}
+}
+
+func rotateLeftJoin[T, K any](a *Node[T, K]) *Node[T, K] {
+	b := a.right
+	a.right = b.left
+	if a.right != nil {
+		a.right.parent = a
+	}
+	b.left = a
+	a.parent = b
+	recomputeNode(a)
+	recomputeNode(b)
+	return b
+}
+
+func rotateRightJoin[T, K any](a *Node[T, K]) *Node[T, K] {
+	b := a.left
+	a.left = b.right
+	if a.left != nil {
+		a.left.parent = a
+	}
+	b.right = a
+	a.parent = b
+	recomputeNode(a)
+	recomputeNode(b)
+	return b
+}
+
+// Split partitions t around key into two Trees in O(log n): left holds
+// every entry whose key compares less than key, right holds every entry
+// whose key compares greater.  An entry with a key equal to key, if
+// present, is dropped.  t (and any Node obtained from it) is consumed by
+// the call and must not be used afterwards.
+func (t *Tree[T, K]) Split(key K) (left, right *Tree[T, K]) {
+	if t.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+
+	l, r := splitNode(t.root, key, t.cmpFn)
+	if l != nil {
+		l.parent = nil
+	}
+	if r != nil {
+		r.parent = nil
+	}
+	return &Tree[T, K]{root: l, cmpFn: t.cmpFn, size: subtreeSize(l)},
+		&Tree[T, K]{root: r, cmpFn: t.cmpFn, size: subtreeSize(r)}
+}
+
+func splitNode[T, K any](n *Node[T, K], key K, cmpFn CompareFunc[K]) (*Node[T, K], *Node[T, K]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	switch cmp := cmpFn(key, n.Key); {
+	case cmp < 0:
+		l, r := splitNode(n.left, key, cmpFn)
+		return l, joinWithKey(r, n.Key, n.Value, n.right, cmpFn)
+	case cmp > 0:
+		l, r := splitNode(n.right, key, cmpFn)
+		return joinWithKey(n.left, n.Key, n.Value, l, cmpFn), r
+	default:
+		return n.left, n.right
+	}
+}
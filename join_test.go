@@ -0,0 +1,191 @@
+// join_test.go - Bulk construction and split/join tests.
+
+package avl
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBuildSortedBalancedAndOrdered(t *testing.T) {
+	keys := make([]int, 100)
+	values := make([]string, 100)
+	for i := range keys {
+		keys[i] = i
+		values[i] = "v"
+	}
+
+	tree := BuildSorted[string, int](cmpInt, keys, values)
+	if tree.Len() != len(keys) {
+		t.Fatalf("Len() = %v, want %v", tree.Len(), len(keys))
+	}
+	if !checkApplicativeBalance(tree.root) {
+		t.Fatalf("BuildSorted: AVL balance invariant violated")
+	}
+
+	var got []int
+	tree.ForEach(Forward, func(n *Node[string, int]) bool {
+		got = append(got, n.Key)
+		return true
+	})
+	for i, k := range got {
+		if k != i {
+			t.Fatalf("BuildSorted order[%v] = %v, want %v", i, k, i)
+		}
+	}
+}
+
+func TestJoinMergesDisjointRanges(t *testing.T) {
+	left := New[string, int](cmpInt)
+	for _, k := range []int{1, 2, 3} {
+		left.Insert(k, "lo")
+	}
+	right := New[string, int](cmpInt)
+	for _, k := range []int{10, 11, 12} {
+		right.Insert(k, "hi")
+	}
+
+	joined := Join(left, right)
+	if joined.Len() != 6 {
+		t.Fatalf("Join: Len() = %v, want 6", joined.Len())
+	}
+	if !checkApplicativeBalance(joined.root) {
+		t.Fatalf("Join: AVL balance invariant violated")
+	}
+
+	var got []int
+	joined.ForEach(Forward, func(n *Node[string, int]) bool {
+		got = append(got, n.Key)
+		return true
+	})
+	want := []int{1, 2, 3, 10, 11, 12}
+	if len(got) != len(want) {
+		t.Fatalf("Join order = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Join order[%v] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if n := joined.Find(2); n == nil || n.Value != "lo" {
+		t.Fatalf("Join: Find(2) = %v, want lo", n)
+	}
+	if n := joined.Find(11); n == nil || n.Value != "hi" {
+		t.Fatalf("Join: Find(11) = %v, want hi", n)
+	}
+}
+
+func TestJoinWithEmptySide(t *testing.T) {
+	empty := New[string, int](cmpInt)
+	right := New[string, int](cmpInt)
+	right.Insert(1, "a")
+
+	joined := Join(empty, right)
+	if joined != right {
+		t.Fatalf("Join(empty, right): expected right returned unchanged")
+	}
+}
+
+func TestSplitPartitionsAroundKey(t *testing.T) {
+	tree := New[string, int](cmpInt)
+	for _, k := range []int{1, 2, 3, 4, 5, 6, 7} {
+		tree.Insert(k, "v")
+	}
+
+	left, right := tree.Split(4)
+
+	if left.Len() != 3 || right.Len() != 3 {
+		t.Fatalf("Split(4): Len() = %v/%v, want 3/3", left.Len(), right.Len())
+	}
+	if !checkApplicativeBalance(left.root) || !checkApplicativeBalance(right.root) {
+		t.Fatalf("Split: AVL balance invariant violated")
+	}
+
+	for _, k := range []int{1, 2, 3} {
+		if left.Find(k) == nil {
+			t.Fatalf("Split: left missing key %v", k)
+		}
+	}
+	for _, k := range []int{5, 6, 7} {
+		if right.Find(k) == nil {
+			t.Fatalf("Split: right missing key %v", k)
+		}
+	}
+	if left.Find(4) != nil || right.Find(4) != nil {
+		t.Fatalf("Split: key 4 should be dropped, found in left or right")
+	}
+
+	rejoined := Join(left, right)
+	if rejoined.Len() != 6 {
+		t.Fatalf("Join after Split: Len() = %v, want 6", rejoined.Len())
+	}
+}
+
+// TestSplitNeedsDoubleRotation reproduces a case where rebalancing a join
+// point requires a double (LR/RL) rotation, not just a single one: the
+// heights on either side of the pivot differ enough that a single rotation
+// would leave |balance| > 1 at the new subtree root.
+func TestSplitNeedsDoubleRotation(t *testing.T) {
+	tree := New[int, int](cmpInt)
+	for _, k := range []int{11, 3, 12, 9, 4, 314, 7} {
+		tree.Insert(k, k)
+	}
+
+	left, right := tree.Split(14)
+	if !checkApplicativeBalance(left.root) {
+		t.Fatalf("Split: left AVL balance invariant violated")
+	}
+	if !checkApplicativeBalance(right.root) {
+		t.Fatalf("Split: right AVL balance invariant violated")
+	}
+}
+
+func TestSplitAndJoinRandomStress(t *testing.T) {
+	const trials = 200
+	const universe = 2000
+
+	for trial := 0; trial < trials; trial++ {
+		n := 1 + rand.Intn(400)
+		perm := rand.Perm(universe)[:n]
+
+		tree := New[int, int](cmpInt)
+		for _, k := range perm {
+			tree.Insert(k, k)
+		}
+
+		splitAt := rand.Intn(universe)
+		left, right := tree.Split(splitAt)
+		if !checkApplicativeBalance(left.root) {
+			t.Fatalf("trial %v: left AVL balance invariant violated after Split(%v)", trial, splitAt)
+		}
+		if !checkApplicativeBalance(right.root) {
+			t.Fatalf("trial %v: right AVL balance invariant violated after Split(%v)", trial, splitAt)
+		}
+		splitTotal := left.Len() + right.Len()
+		if splitTotal != n && splitTotal != n-1 {
+			t.Fatalf("trial %v: Split: left.Len()+right.Len() = %v, want %v or %v", trial, splitTotal, n, n-1)
+		}
+
+		// Join consumes and mutates left (it removes left's last key to use
+		// as the new join pivot), so capture the expected total beforehand.
+		joined := Join(left, right)
+		if !checkApplicativeBalance(joined.root) {
+			t.Fatalf("trial %v: joined AVL balance invariant violated", trial)
+		}
+		if joined.Len() != splitTotal {
+			t.Fatalf("trial %v: Join: Len() = %v, want %v", trial, joined.Len(), splitTotal)
+		}
+
+		var inOrder []int
+		joined.ForEach(Forward, func(node *Node[int, int]) bool {
+			inOrder = append(inOrder, node.Key)
+			return true
+		})
+		for i := 1; i < len(inOrder); i++ {
+			if inOrder[i-1] >= inOrder[i] {
+				t.Fatalf("trial %v: joined tree not in order at %v: %v >= %v", trial, i, inOrder[i-1], inOrder[i])
+			}
+		}
+	}
+}
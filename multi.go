@@ -0,0 +1,82 @@
+// multi.go - Multimap semantics: Trees that allow duplicate keys.
+
+package avl
+
+// NewMulti returns an initialized Tree that allows multiple Nodes to share
+// the same key (a multimap).  Insert and InsertNode break ties by always
+// descending right instead of returning the existing Node, so repeated
+// Insert calls with an equal key accumulate a run of Nodes rather than being
+// no-ops.  Plain Find returns an arbitrary match from that run; use
+// FindFirst, FindLast, EqualRange, or CountKey to work with the run as a
+// whole.
+func NewMulti[T, K any](cmpFn CompareFunc[K]) *Tree[T, K] {
+	if cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	return &Tree[T, K]{cmpFn: cmpFn, allowDup: true}
+}
+
+// FindFirst returns the first (smallest-positioned) Node in t with the given
+// key, or nil if key is not present.  On a Tree not created via NewMulti
+// this is equivalent to Find.
+func (t *Tree[T, K]) FindFirst(key K) *Node[T, K] {
+	if t.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	n := t.boundedSearch(key, +1, false)
+	if n == nil || t.cmpFn(key, n.Key) != 0 {
+		return nil
+	}
+	return n
+}
+
+// FindLast returns the last (largest-positioned) Node in t with the given
+// key, or nil if key is not present.
+func (t *Tree[T, K]) FindLast(key K) *Node[T, K] {
+	if t.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	n := t.boundedSearch(key, -1, false)
+	if n == nil || t.cmpFn(key, n.Key) != 0 {
+		return nil
+	}
+	return n
+}
+
+// EqualRange returns a pair of Forward Iterators bounding the run of Nodes
+// in t whose key equals key: first is positioned at the run's first Node
+// (or at the first Node past where key would be, if key is absent), and
+// last is positioned one past the run - the conventional [first, last)
+// equal-range idiom.  Exhaust the range by alternating Get/Next on first
+// until it reaches the Node last.Get() currently points to.
+func (t *Tree[T, K]) EqualRange(key K) (first, last *Iterator[T, K]) {
+	first = t.Iterator(Forward)
+	first.LowerBound(key)
+	last = t.Iterator(Forward)
+	last.UpperBound(key)
+	return first, last
+}
+
+// CountKey returns the number of Nodes in t whose key equals key, in
+// O(log n) via the cached subtree sizes.
+func (t *Tree[T, K]) CountKey(key K) int {
+	if t.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	return t.rankUpperBound(key) - t.Rank(key)
+}
+
+// rankUpperBound returns the number of Nodes in t whose key is <= key.
+func (t *Tree[T, K]) rankUpperBound(key K) int {
+	rank := 0
+	cur := t.root
+	for cur != nil {
+		if t.cmpFn(key, cur.Key) < 0 {
+			cur = cur.left
+		} else {
+			rank += subtreeSize(cur.left) + 1
+			cur = cur.right
+		}
+	}
+	return rank
+}
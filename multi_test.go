@@ -0,0 +1,98 @@
+// multi_test.go - Multimap semantics tests.
+
+package avl
+
+import "testing"
+
+func TestMultiInsertAllowsDuplicates(t *testing.T) {
+	tree := NewMulti[string, int](cmpInt)
+
+	tree.Insert(1, "a")
+	tree.Insert(1, "b")
+	tree.Insert(1, "c")
+	tree.Insert(2, "x")
+
+	if tree.Len() != 4 {
+		t.Fatalf("Len() = %v, want 4", tree.Len())
+	}
+	if c := tree.CountKey(1); c != 3 {
+		t.Fatalf("CountKey(1) = %v, want 3", c)
+	}
+	if c := tree.CountKey(2); c != 1 {
+		t.Fatalf("CountKey(2) = %v, want 1", c)
+	}
+	if c := tree.CountKey(3); c != 0 {
+		t.Fatalf("CountKey(3) = %v, want 0", c)
+	}
+}
+
+func TestMultiFindFirstLast(t *testing.T) {
+	tree := NewMulti[string, int](cmpInt)
+	for _, v := range []string{"a", "b", "c"} {
+		tree.Insert(5, v)
+	}
+	tree.Insert(1, "lo")
+	tree.Insert(9, "hi")
+
+	first := tree.FindFirst(5)
+	last := tree.FindLast(5)
+	if first == nil || last == nil {
+		t.Fatalf("FindFirst/FindLast(5) returned nil")
+	}
+	if first == last {
+		t.Fatalf("FindFirst/FindLast(5) returned the same Node for 3 duplicates")
+	}
+	if first.Value != "a" || last.Value != "c" {
+		t.Fatalf("FindFirst/FindLast(5) = %v/%v, want a/c", first.Value, last.Value)
+	}
+
+	if tree.FindFirst(100) != nil || tree.FindLast(100) != nil {
+		t.Fatalf("FindFirst/FindLast(100): expected nil for absent key")
+	}
+}
+
+func TestMultiEqualRange(t *testing.T) {
+	tree := NewMulti[string, int](cmpInt)
+	tree.Insert(1, "lo")
+	for _, v := range []string{"a", "b", "c"} {
+		tree.Insert(5, v)
+	}
+	tree.Insert(9, "hi")
+
+	first, last := tree.EqualRange(5)
+	var got []string
+	for n := first.Get(); n != last.Get(); n = first.Next() {
+		got = append(got, n.Value)
+	}
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("EqualRange(5) visited %v, want [a b c]", got)
+	}
+
+	emptyFirst, emptyLast := tree.EqualRange(100)
+	if emptyFirst.Get() != emptyLast.Get() {
+		t.Fatalf("EqualRange(100): expected an empty range for an absent key")
+	}
+}
+
+func TestMultiRemoveByIdentity(t *testing.T) {
+	tree := NewMulti[string, int](cmpInt)
+	a := tree.Insert(5, "a")
+	tree.Insert(5, "b")
+	c := tree.Insert(5, "c")
+
+	tree.Remove(a)
+	if tree.CountKey(5) != 2 {
+		t.Fatalf("CountKey(5) after removing one duplicate = %v, want 2", tree.CountKey(5))
+	}
+	if tree.FindFirst(5) == a {
+		t.Fatalf("Remove: removed Node still reachable via FindFirst")
+	}
+
+	tree.Remove(c)
+	if tree.CountKey(5) != 1 {
+		t.Fatalf("CountKey(5) after removing second duplicate = %v, want 1", tree.CountKey(5))
+	}
+	if n := tree.FindFirst(5); n == nil || n.Value != "b" {
+		t.Fatalf("FindFirst(5) = %v, want b", n)
+	}
+}
@@ -0,0 +1,95 @@
+// order_stat.go - Order-statistics and interval queries backed by the
+// subtree size cached on each Node.
+
+package avl
+
+// Rank returns the number of Nodes in the Tree whose key compares less than
+// key, i.e. the 0-based in-order position key would occupy if it were
+// present.  It runs in O(log n).
+func (t *Tree[T, K]) Rank(key K) int {
+	if t.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+
+	rank := 0
+	cur := t.root
+	for cur != nil {
+		cmp := t.cmpFn(key, cur.Key)
+		switch {
+		case cmp <= 0:
+			cur = cur.left
+		default:
+			rank += subtreeSize(cur.left) + 1
+			cur = cur.right
+		}
+	}
+	return rank
+}
+
+// Select returns the i-th (0-based) Node in in-order position, or nil if i
+// is out of range.  It runs in O(log n).
+func (t *Tree[T, K]) Select(i int) *Node[T, K] {
+	if i < 0 || i >= t.size {
+		return nil
+	}
+
+	cur := t.root
+	for cur != nil {
+		lsize := subtreeSize(cur.left)
+		switch {
+		case i < lsize:
+			cur = cur.left
+		case i == lsize:
+			return cur
+		default:
+			i -= lsize + 1
+			cur = cur.right
+		}
+	}
+	return nil
+}
+
+// CountRange returns the number of Nodes in the Tree whose key k satisfies
+// lo <= k <= hi.  It runs in O(log n).
+func (t *Tree[T, K]) CountRange(lo, hi K) int {
+	if t.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	if t.cmpFn(lo, hi) > 0 {
+		return 0
+	}
+	return t.rankUpperBound(hi) - t.Rank(lo)
+}
+
+// Range invokes fn, in-order, for every Node whose key k satisfies
+// lo <= k <= hi, descending only into subtrees that can overlap [lo, hi].
+// If fn returns false, the walk stops early.
+func (t *Tree[T, K]) Range(lo, hi K, fn func(*Node[T, K]) bool) {
+	if t.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	t.rangeNode(t.root, lo, hi, fn)
+}
+
+func (t *Tree[T, K]) rangeNode(n *Node[T, K], lo, hi K, fn func(*Node[T, K]) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	if t.cmpFn(lo, n.Key) <= 0 {
+		if !t.rangeNode(n.left, lo, hi, fn) {
+			return false
+		}
+	}
+	if t.cmpFn(lo, n.Key) <= 0 && t.cmpFn(hi, n.Key) >= 0 {
+		if !fn(n) {
+			return false
+		}
+	}
+	if t.cmpFn(hi, n.Key) >= 0 {
+		if !t.rangeNode(n.right, lo, hi, fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+// order_stat_test.go - Order-statistics and interval query tests.
+
+package avl
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestTreeRankSelect(t *testing.T) {
+	tree := New[int, int](cmpInt)
+	const n = 256
+	seen := make(map[int]bool)
+	var sorted []int
+	for len(seen) < n {
+		v := rand.Intn(100000)
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		sorted = append(sorted, v)
+		tree.Insert(v, v)
+	}
+	sort.Ints(sorted)
+
+	for i, k := range sorted {
+		if rank := tree.Rank(k); rank != i {
+			t.Fatalf("Rank(%v) = %v, want %v", k, rank, i)
+		}
+		if node := tree.Select(i); node == nil || node.Key != k {
+			t.Fatalf("Select(%v) = %v, want %v", i, node, k)
+		}
+	}
+	if tree.Select(-1) != nil || tree.Select(n) != nil {
+		t.Fatalf("Select: expected nil for out-of-range index")
+	}
+
+	// Rank/Select must stay consistent across removals.
+	for i := 0; i < n/2; i++ {
+		tree.Remove(tree.Find(sorted[i]))
+	}
+	remaining := sorted[n/2:]
+	for i, k := range remaining {
+		if rank := tree.Rank(k); rank != i {
+			t.Fatalf("Rank(%v) after removal = %v, want %v", k, rank, i)
+		}
+	}
+}
+
+func TestTreeCountRangeAndRange(t *testing.T) {
+	tree := New[int, int](cmpInt)
+	for _, v := range []int{10, 20, 30, 40, 50, 60, 70} {
+		tree.Insert(v, v*2)
+	}
+
+	if c := tree.CountRange(20, 50); c != 4 {
+		t.Fatalf("CountRange(20, 50) = %v, want 4", c)
+	}
+	if c := tree.CountRange(25, 45); c != 2 {
+		t.Fatalf("CountRange(25, 45) = %v, want 2", c)
+	}
+	if c := tree.CountRange(100, 200); c != 0 {
+		t.Fatalf("CountRange(100, 200) = %v, want 0", c)
+	}
+
+	var visited []int
+	tree.Range(20, 50, func(n *Node[int, int]) bool {
+		visited = append(visited, n.Key)
+		return true
+	})
+	want := []int{20, 30, 40, 50}
+	if len(visited) != len(want) {
+		t.Fatalf("Range(20, 50) visited %v, want %v", visited, want)
+	}
+	for i := range want {
+		if visited[i] != want[i] {
+			t.Fatalf("Range(20, 50)[%v] = %v, want %v", i, visited[i], want[i])
+		}
+	}
+
+	visited = nil
+	tree.Range(20, 50, func(n *Node[int, int]) bool {
+		visited = append(visited, n.Key)
+		return n.Key < 30
+	})
+	if len(visited) != 2 {
+		t.Fatalf("Range: early stop visited %v nodes, want 2", len(visited))
+	}
+}
@@ -0,0 +1,337 @@
+// persistent.go - Applicative (path-copying) AVL snapshots.
+
+package avl
+
+// Persistent is an immutable, structurally-shared AVL tree.  Insert and
+// Remove never mutate the receiver; instead they return a new Persistent
+// that shares every subtree the update did not touch with the original, at
+// the cost of O(log n) freshly allocated nodes along the modified path.
+// Holding on to an older Persistent therefore costs nothing beyond the
+// reference itself, and never observes later updates - a cheap alternative
+// to a deep copy for transactional snapshots.
+type Persistent[T, K any] struct {
+	root  *Node[T, K]
+	cmpFn CompareFunc[K]
+	size  int
+}
+
+// NewPersistent returns an empty Persistent tree using cmpFn for ordering.
+func NewPersistent[T, K any](cmpFn CompareFunc[K]) *Persistent[T, K] {
+	if cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	return &Persistent[T, K]{cmpFn: cmpFn}
+}
+
+// Snapshot returns an independent Persistent view of t's current contents.
+// It is an O(n) deep copy rather than a cheap path-copy, since t's nodes are
+// mutated in place and so cannot safely be shared; subsequent Insert/Remove
+// calls on the returned Persistent are O(log n) and share structure with
+// each other, but never with t.
+func (t *Tree[T, K]) Snapshot() *Persistent[T, K] {
+	return &Persistent[T, K]{
+		root:  copyForSnapshot[T, K](t.root, nil),
+		cmpFn: t.cmpFn,
+		size:  t.size,
+	}
+}
+
+func copyForSnapshot[T, K any](n, parent *Node[T, K]) *Node[T, K] {
+	if n == nil {
+		return nil
+	}
+	c := &Node[T, K]{Value: n.Value, Key: n.Key, parent: parent}
+	c.left = copyForSnapshot(n.left, c)
+	c.right = copyForSnapshot(n.right, c)
+	updateHeight(c)
+	return c
+}
+
+// Len returns the number of elements in p.
+func (p *Persistent[T, K]) Len() int {
+	return p.size
+}
+
+// Find finds key in p, and returns the Node or nil iff the key is not
+// present.
+func (p *Persistent[T, K]) Find(key K) *Node[T, K] {
+	cur := p.root
+	for cur != nil {
+		cmp := p.cmpFn(key, cur.Key)
+		switch {
+		case cmp < 0:
+			cur = cur.left
+		case cmp > 0:
+			cur = cur.right
+		default:
+			return cur
+		}
+	}
+	return cur
+}
+
+// First returns the first Node in p (in-order) or nil iff p is empty.
+func (p *Persistent[T, K]) First() *Node[T, K] {
+	return firstOrLast(p.root, -1)
+}
+
+// Last returns the last Node in p (in-order) or nil iff p is empty.
+func (p *Persistent[T, K]) Last() *Node[T, K] {
+	return firstOrLast(p.root, +1)
+}
+
+func firstOrLast[T, K any](n *Node[T, K], sign int) *Node[T, K] {
+	if n == nil {
+		return nil
+	}
+	for {
+		next := n.getChild(sign)
+		if next == nil {
+			return n
+		}
+		n = next
+	}
+}
+
+// ForEach executes fn for each Node in p, in-order.  If fn returns false,
+// the walk stops early.
+func (p *Persistent[T, K]) ForEach(fn func(*Node[T, K]) bool) {
+	walkInOrder(p.root, fn)
+}
+
+func walkInOrder[T, K any](n *Node[T, K], fn func(*Node[T, K]) bool) bool {
+	if n == nil {
+		return true
+	}
+	if !walkInOrder(n.left, fn) {
+		return false
+	}
+	if !fn(n) {
+		return false
+	}
+	return walkInOrder(n.right, fn)
+}
+
+// Insert returns a new Persistent with (k, v) inserted, sharing every
+// subtree the insertion did not touch with p.  If k is already present, p
+// is returned unchanged.
+func (p *Persistent[T, K]) Insert(k K, v T) *Persistent[T, K] {
+	if p.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	newRoot, inserted := insertApplicative(p.root, p.cmpFn, k, v)
+	if !inserted {
+		return p
+	}
+	return &Persistent[T, K]{root: newRoot, cmpFn: p.cmpFn, size: p.size + 1}
+}
+
+// Remove returns a new Persistent with key removed, sharing every subtree
+// the removal did not touch with p.  If key is not present, p is returned
+// unchanged.
+func (p *Persistent[T, K]) Remove(key K) *Persistent[T, K] {
+	if p.cmpFn == nil {
+		panic(errNoCmpFn)
+	}
+	newRoot, removed := removeApplicative(p.root, p.cmpFn, key)
+	if !removed {
+		return p
+	}
+	return &Persistent[T, K]{root: newRoot, cmpFn: p.cmpFn, size: p.size - 1}
+}
+
+// Equal reports whether p and other contain the same keys with equal (per
+// eq) values.  The comparison is by key/value content, not by tree shape:
+// two Persistent trees holding the same keys and values can still have
+// different internal shapes (e.g. after an Insert/Remove pair of the same
+// key takes a different rebalancing path), and still compare equal.
+func (p *Persistent[T, K]) Equal(other *Persistent[T, K], eq func(a, b T) bool) bool {
+	if p.size != other.size {
+		return false
+	}
+	return len(p.DiffFrom(other, eq)) == 0
+}
+
+// DiffFrom returns the Nodes that are present in p but either absent from
+// base or present with a different (per eq) value, in O(n log n) via a
+// Find in base per Node of p.  As a fast path, DiffFrom returns immediately
+// if p and base share their root by pointer identity (e.g. base was derived
+// from p without touching any key), since two Persistent trees can only
+// share a root by construction when they hold identical content.
+func (p *Persistent[T, K]) DiffFrom(base *Persistent[T, K], eq func(a, b T) bool) []*Node[T, K] {
+	if p.root == base.root {
+		return nil
+	}
+	var out []*Node[T, K]
+	walkInOrder(p.root, func(n *Node[T, K]) bool {
+		if other := base.Find(n.Key); other == nil || !eq(n.Value, other.Value) {
+			out = append(out, n)
+		}
+		return true
+	})
+	return out
+}
+
+// updateHeight derives n.height, n.balance, and n.size from n's children,
+// which must already be correct.  size is kept up to date here too (even
+// though no Persistent method reads it) so that a Node reached through a
+// Persistent is never stale for a size-based query.
+func updateHeight[T, K any](n *Node[T, K]) {
+	lh, rh := heightOf(n.left), heightOf(n.right)
+	if lh > rh {
+		n.height = lh + 1
+	} else {
+		n.height = rh + 1
+	}
+	n.balance = rh - lh
+	n.size = 1 + subtreeSize(n.left) + subtreeSize(n.right)
+}
+
+func heightOf[T, K any](n *Node[T, K]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func copyForPathEdit[T, K any](n *Node[T, K]) *Node[T, K] {
+	c := *n
+	c.parent = nil
+	return &c
+}
+
+func insertApplicative[T, K any](n *Node[T, K], cmpFn CompareFunc[K], k K, v T) (*Node[T, K], bool) {
+	if n == nil {
+		return &Node[T, K]{Value: v, Key: k, height: 1, size: 1}, true
+	}
+
+	cmp := cmpFn(k, n.Key)
+	if cmp == 0 {
+		return n, false
+	}
+
+	c := copyForPathEdit(n)
+	if cmp < 0 {
+		newLeft, inserted := insertApplicative(n.left, cmpFn, k, v)
+		if !inserted {
+			return n, false
+		}
+		c.left, newLeft.parent = newLeft, c
+	} else {
+		newRight, inserted := insertApplicative(n.right, cmpFn, k, v)
+		if !inserted {
+			return n, false
+		}
+		c.right, newRight.parent = newRight, c
+	}
+	updateHeight(c)
+	return rebalanceApplicative(c), true
+}
+
+func removeApplicative[T, K any](n *Node[T, K], cmpFn CompareFunc[K], key K) (*Node[T, K], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	cmp := cmpFn(key, n.Key)
+	switch {
+	case cmp < 0:
+		newLeft, removed := removeApplicative(n.left, cmpFn, key)
+		if !removed {
+			return n, false
+		}
+		c := copyForPathEdit(n)
+		c.left = newLeft
+		if newLeft != nil {
+			newLeft.parent = c
+		}
+		updateHeight(c)
+		return rebalanceApplicative(c), true
+	case cmp > 0:
+		newRight, removed := removeApplicative(n.right, cmpFn, key)
+		if !removed {
+			return n, false
+		}
+		c := copyForPathEdit(n)
+		c.right = newRight
+		if newRight != nil {
+			newRight.parent = c
+		}
+		updateHeight(c)
+		return rebalanceApplicative(c), true
+	default:
+		switch {
+		case n.left == nil:
+			return detachRoot(n.right), true
+		case n.right == nil:
+			return detachRoot(n.left), true
+		default:
+			succ := firstOrLast(n.right, -1)
+			newRight, _ := removeApplicative(n.right, cmpFn, succ.Key)
+			c := &Node[T, K]{Value: succ.Value, Key: succ.Key, left: n.left, right: newRight}
+			if c.left != nil {
+				c.left.parent = c
+			}
+			if c.right != nil {
+				c.right.parent = c
+			}
+			updateHeight(c)
+			return rebalanceApplicative(c), true
+		}
+	}
+}
+
+func detachRoot[T, K any](n *Node[T, K]) *Node[T, K] {
+	if n == nil {
+		return nil
+	}
+	c := copyForPathEdit(n)
+	return c
+}
+
+func rebalanceApplicative[T, K any](n *Node[T, K]) *Node[T, K] {
+	switch {
+	case n.balance > 1:
+		if n.right.balance < 0 {
+			n.right = rotateRightApplicative(n.right)
+			n.right.parent = n
+		}
+		return rotateLeftApplicative(n)
+	case n.balance < -1:
+		if n.left.balance > 0 {
+			n.left = rotateLeftApplicative(n.left)
+			n.left.parent = n
+		}
+		return rotateRightApplicative(n)
+	default:
+		return n
+	}
+}
+
+func rotateLeftApplicative[T, K any](a *Node[T, K]) *Node[T, K] {
+	a = copyForPathEdit(a)
+	b := copyForPathEdit(a.right)
+	a.right = b.left
+	if a.right != nil {
+		a.right.parent = a
+	}
+	b.left = a
+	a.parent = b
+	updateHeight(a)
+	updateHeight(b)
+	return b
+}
+
+func rotateRightApplicative[T, K any](a *Node[T, K]) *Node[T, K] {
+	a = copyForPathEdit(a)
+	b := copyForPathEdit(a.left)
+	a.left = b.right
+	if a.left != nil {
+		a.left.parent = a
+	}
+	b.right = a
+	a.parent = b
+	updateHeight(a)
+	updateHeight(b)
+	return b
+}
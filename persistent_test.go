@@ -0,0 +1,228 @@
+// persistent_test.go - Persistent AVL tree tests.
+
+package avl
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func cmpInt(a, b int) int {
+	return a - b
+}
+
+func TestPersistentInsertSharing(t *testing.T) {
+	p0 := NewPersistent[int, int](cmpInt)
+	if p0.Len() != 0 || p0.Find(1) != nil {
+		t.Fatalf("NewPersistent: expected empty tree")
+	}
+
+	p1 := p0
+	for _, k := range []int{2, 1, 3} {
+		p1 = p1.Insert(k, k*100)
+	}
+	p2 := p1.Insert(4, 400)
+
+	if p0.Len() != 0 {
+		t.Fatalf("Insert: mutated p0, Len() = %v", p0.Len())
+	}
+	if p1.Len() != 3 || p1.Find(4) != nil {
+		t.Fatalf("Insert: p1 observed p2's update")
+	}
+	if p2.Len() != 4 || p2.Find(1) == nil || p2.Find(4) == nil {
+		t.Fatalf("Insert: p2 missing expected keys")
+	}
+
+	// Inserting 4 only copies the path from the root down through the
+	// right subtree; p1's untouched left subtree (the Node for key 1) is
+	// shared by pointer identity with p2 rather than copied.
+	if p1.root.left != p2.root.left {
+		t.Fatalf("Insert: expected structural sharing between p1 and p2")
+	}
+	if p1.root == p2.root {
+		t.Fatalf("Insert: expected p2's root to be a fresh copy, not aliasing p1's")
+	}
+}
+
+func TestPersistentRemove(t *testing.T) {
+	p := NewPersistent[int, int](cmpInt)
+	for _, k := range []int{5, 3, 8, 1, 4, 7, 9} {
+		p = p.Insert(k, k*10)
+	}
+
+	before := p
+	after := p.Remove(3)
+	if after.Len() != before.Len()-1 {
+		t.Fatalf("Remove: Len() = %v, want %v", after.Len(), before.Len()-1)
+	}
+	if before.Find(3) == nil {
+		t.Fatalf("Remove: mutated before snapshot")
+	}
+	if after.Find(3) != nil {
+		t.Fatalf("Remove: key 3 still present after removal")
+	}
+	for _, k := range []int{5, 8, 1, 4, 7, 9} {
+		if after.Find(k) == nil {
+			t.Fatalf("Remove: key %v missing after unrelated removal", k)
+		}
+	}
+
+	// Removing a key that isn't present shares everything with the input.
+	same := after.Remove(3)
+	if same != after {
+		t.Fatalf("Remove: expected no-op Remove to return the receiver unchanged")
+	}
+}
+
+func TestPersistentRemoveDoesNotMutateSharedSubtree(t *testing.T) {
+	p := NewPersistent[int, int](cmpInt)
+	seen := make(map[int]bool)
+	for len(seen) < 40 {
+		v := rand.Intn(10000)
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		p = p.Insert(v, v*10)
+	}
+
+	var keys []int
+	p.ForEach(func(node *Node[int, int]) bool {
+		keys = append(keys, node.Key)
+		return true
+	})
+
+	original := p
+	derived := p
+	for i := 0; i < 10 && i < len(keys); i++ {
+		derived = derived.Remove(keys[i])
+	}
+
+	var gotAfter []int
+	original.ForEach(func(node *Node[int, int]) bool {
+		gotAfter = append(gotAfter, node.Key)
+		return true
+	})
+	if !reflect.DeepEqual(keys, gotAfter) {
+		t.Fatalf("Remove: original snapshot mutated by a later lineage's rebalancing, got %v, want %v", gotAfter, keys)
+	}
+	if !checkApplicativeSize(original.root) || subtreeSize(original.root) != len(keys) {
+		t.Fatalf("Remove: original snapshot's Node.size corrupted by a later lineage's rebalancing")
+	}
+	if !checkApplicativeSize(derived.root) {
+		t.Fatalf("Remove: Node.size not maintained along the applicative Remove path")
+	}
+}
+
+func TestPersistentOrderAndBalance(t *testing.T) {
+	p := NewPersistent[int, int](cmpInt)
+	const n = 512
+	seen := make(map[int]bool)
+	for len(seen) < n {
+		v := rand.Intn(10000)
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		p = p.Insert(v, v)
+	}
+
+	var inOrder []int
+	p.ForEach(func(node *Node[int, int]) bool {
+		inOrder = append(inOrder, node.Key)
+		return true
+	})
+	if len(inOrder) != n {
+		t.Fatalf("ForEach: visited %v nodes, want %v", len(inOrder), n)
+	}
+	for i := 1; i < len(inOrder); i++ {
+		if inOrder[i-1] >= inOrder[i] {
+			t.Fatalf("ForEach: not in order at %v: %v >= %v", i, inOrder[i-1], inOrder[i])
+		}
+	}
+
+	if !checkApplicativeBalance(p.root) {
+		t.Fatalf("Insert: AVL balance invariant violated")
+	}
+	if !checkApplicativeSize(p.root) || subtreeSize(p.root) != n {
+		t.Fatalf("Insert: Node.size not maintained along the applicative path")
+	}
+}
+
+// checkApplicativeSize reports whether every Node's size field matches
+// 1 + the size of its children, recursively.
+func checkApplicativeSize[T, K any](n *Node[T, K]) bool {
+	if n == nil {
+		return true
+	}
+	if n.size != 1+subtreeSize(n.left)+subtreeSize(n.right) {
+		return false
+	}
+	return checkApplicativeSize(n.left) && checkApplicativeSize(n.right)
+}
+
+func checkApplicativeBalance[T, K any](n *Node[T, K]) bool {
+	if n == nil {
+		return true
+	}
+	lh, rh := heightOf(n.left), heightOf(n.right)
+	diff := rh - lh
+	if diff < -1 || diff > 1 {
+		return false
+	}
+	return checkApplicativeBalance(n.left) && checkApplicativeBalance(n.right)
+}
+
+func TestPersistentEqualAndDiffFrom(t *testing.T) {
+	p := NewPersistent[string, int](cmpInt)
+	for _, k := range []int{1, 2, 3, 4, 5} {
+		p = p.Insert(k, "v")
+	}
+
+	same := p.Insert(6, "v").Remove(6)
+	if !p.Equal(same, func(a, b string) bool { return a == b }) {
+		t.Fatalf("Equal: expected equivalent trees to compare equal")
+	}
+	if diff := p.DiffFrom(same, func(a, b string) bool { return a == b }); len(diff) != 0 {
+		t.Fatalf("DiffFrom: expected no diffs between equivalent trees, got %v", len(diff))
+	}
+
+	// Insert is a no-op on an already-present key, so force a value change
+	// by removing key 3 and re-inserting it with a different value.
+	changed := p.Remove(3).Insert(3, "updated")
+	if p.Equal(changed, func(a, b string) bool { return a == b }) {
+		t.Fatalf("Equal: expected modified tree to compare unequal")
+	}
+	diff := changed.DiffFrom(p, func(a, b string) bool { return a == b })
+	if len(diff) != 1 || diff[0].Key != 3 || diff[0].Value != "updated" {
+		t.Fatalf("DiffFrom: got %v, want a single changed node for key 3", diff)
+	}
+}
+
+func TestTreeSnapshotIndependence(t *testing.T) {
+	tree := New[int, int](cmpInt)
+	for _, k := range []int{1, 2, 3} {
+		tree.Insert(k, k)
+	}
+
+	snap := tree.Snapshot()
+	if n := tree.Find(2); n == nil {
+		tree.Insert(2, 2)
+	}
+	tree.Remove(tree.Find(2))
+	tree.Insert(4, 4)
+
+	if snap.Find(2) == nil {
+		t.Fatalf("Snapshot: mutation of source Tree observed by snapshot (key 2 missing)")
+	}
+	if snap.Find(4) != nil {
+		t.Fatalf("Snapshot: mutation of source Tree observed by snapshot (key 4 present)")
+	}
+	if !reflect.DeepEqual([]int{1, 2, 3}, []int{snap.First().Key, 2, snap.Last().Key}) {
+		t.Fatalf("Snapshot: unexpected bounds")
+	}
+	if !checkApplicativeSize(snap.root) || subtreeSize(snap.root) != 3 {
+		t.Fatalf("Snapshot: Node.size not maintained by copyForSnapshot")
+	}
+}